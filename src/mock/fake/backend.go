@@ -0,0 +1,92 @@
+// Package fake implements a minimal, stateful in-memory GitHub backend.
+//
+// Unlike the canned-response mocks in the parent `mock` package, a Backend
+// remembers what was created against it: opening an issue through
+// `POST /repos/{owner}/{repo}/issues` allocates a number and persists it, so
+// a following `GET .../issues/{number}` returns it, `PATCH` mutates it, and
+// `GET .../issues` lists it. This lets tests exercise multi-step workflows
+// (open issue -> comment -> close) without scripting every response.
+package fake
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/antonlisovenko/go-github-mock/src/mock"
+)
+
+// Backend is a stateful in-memory GitHub API. It owns one store per
+// resource kind and wires their handlers onto the EndpointPattern table
+// the parent `mock` package already routes REST calls through.
+type Backend struct {
+	mu sync.Mutex
+
+	repos    *repoStore
+	issues   *issueStore
+	labels   *labelStore
+	comments *commentStore
+}
+
+// NewFakeGitHubBackend creates an empty Backend with no repositories,
+// issues, pull requests, labels, or comments.
+func NewFakeGitHubBackend() *Backend {
+	return &Backend{
+		repos:    newRepoStore(),
+		issues:   newIssueStore(),
+		labels:   newLabelStore(),
+		comments: newCommentStore(),
+	}
+}
+
+// WithRepo seeds the backend with an existing repository, so tests can
+// pre-populate fixtures before issuing requests against it.
+func (b *Backend) WithRepo(owner, repo string) *Backend {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.repos.getOrCreate(owner, repo)
+
+	return b
+}
+
+// NewMockedHTTPClient returns an *http.Client backed by this Backend's
+// in-memory state, in addition to any extra options (e.g. canned responses
+// for endpoints the fake backend does not implement).
+func (b *Backend) NewMockedHTTPClient(extra ...mock.MockBackendOption) *http.Client {
+	options := append([]mock.MockBackendOption{
+		mock.WithRequestMatchHandler(
+			mock.GetReposIssuesByOwnerByRepo,
+			http.HandlerFunc(b.listIssues),
+		),
+		mock.WithRequestMatchHandler(
+			mock.PostReposIssuesByOwnerByRepo,
+			http.HandlerFunc(b.createIssue),
+		),
+		mock.WithRequestMatchHandler(
+			mock.GetReposIssuesByOwnerByRepoByIssueNumber,
+			http.HandlerFunc(b.getIssue),
+		),
+		mock.WithRequestMatchHandler(
+			mock.PatchReposIssuesByOwnerByRepoByIssueNumber,
+			http.HandlerFunc(b.updateIssue),
+		),
+		mock.WithRequestMatchHandler(
+			mock.GetReposIssuesCommentsByOwnerByRepoByIssueNumber,
+			http.HandlerFunc(b.listComments),
+		),
+		mock.WithRequestMatchHandler(
+			mock.PostReposIssuesCommentsByOwnerByRepoByIssueNumber,
+			http.HandlerFunc(b.createComment),
+		),
+		mock.WithRequestMatchHandler(
+			mock.GetReposLabelsByOwnerByRepo,
+			http.HandlerFunc(b.listLabels),
+		),
+		mock.WithRequestMatchHandler(
+			mock.PostReposLabelsByOwnerByRepo,
+			http.HandlerFunc(b.createLabel),
+		),
+	}, extra...)
+
+	return mock.NewMockedHTTPClient(options...)
+}
@@ -0,0 +1,176 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-github/v37/github"
+)
+
+func TestFakeGitHubBackendIssueWorkflow(t *testing.T) {
+	backend := NewFakeGitHubBackend()
+	c := github.NewClient(backend.NewMockedHTTPClient())
+	ctx := context.Background()
+
+	created, _, err := c.Issues.Create(ctx, "o", "r", &github.IssueRequest{
+		Title:  github.String("something is broken"),
+		Labels: &[]string{"bug"},
+	})
+
+	if err != nil {
+		t.Fatalf("create issue err is %s, want nil", err.Error())
+	}
+
+	if created.GetNumber() != 1 {
+		t.Errorf("issue number is %d, want 1", created.GetNumber())
+	}
+
+	fetched, _, err := c.Issues.Get(ctx, "o", "r", created.GetNumber())
+
+	if err != nil {
+		t.Fatalf("get issue err is %s, want nil", err.Error())
+	}
+
+	if fetched.GetTitle() != "something is broken" {
+		t.Errorf("issue title is %s, want 'something is broken'", fetched.GetTitle())
+	}
+
+	if len(fetched.Labels) != 1 || fetched.Labels[0].GetName() != "bug" {
+		t.Errorf("issue labels are %+v, want a single 'bug' label", fetched.Labels)
+	}
+
+	comment, _, err := c.Issues.CreateComment(ctx, "o", "r", created.GetNumber(), &github.IssueComment{
+		Body: github.String("looking into it"),
+	})
+
+	if err != nil {
+		t.Fatalf("create comment err is %s, want nil", err.Error())
+	}
+
+	if comment.GetBody() != "looking into it" {
+		t.Errorf("comment body is %s, want 'looking into it'", comment.GetBody())
+	}
+
+	closed, _, err := c.Issues.Edit(ctx, "o", "r", created.GetNumber(), &github.IssueRequest{
+		State: github.String("closed"),
+	})
+
+	if err != nil {
+		t.Fatalf("edit issue err is %s, want nil", err.Error())
+	}
+
+	if closed.GetState() != "closed" {
+		t.Errorf("issue state is %s, want closed", closed.GetState())
+	}
+
+	openIssues, _, err := c.Issues.ListByRepo(ctx, "o", "r", &github.IssueListByRepoOptions{State: "open"})
+
+	if err != nil {
+		t.Fatalf("list issues err is %s, want nil", err.Error())
+	}
+
+	if len(openIssues) != 0 {
+		t.Errorf("open issues len is %d, want 0", len(openIssues))
+	}
+
+	closedIssues, _, err := c.Issues.ListByRepo(ctx, "o", "r", &github.IssueListByRepoOptions{State: "closed"})
+
+	if err != nil {
+		t.Fatalf("list issues err is %s, want nil", err.Error())
+	}
+
+	if len(closedIssues) != 1 {
+		t.Errorf("closed issues len is %d, want 1", len(closedIssues))
+	}
+}
+
+func TestFakeGitHubBackendConditionalGet(t *testing.T) {
+	backend := NewFakeGitHubBackend()
+	c := github.NewClient(backend.NewMockedHTTPClient())
+	ctx := context.Background()
+
+	created, _, err := c.Issues.Create(ctx, "o", "r", &github.IssueRequest{Title: github.String("first")})
+
+	if err != nil {
+		t.Fatalf("create issue err is %s, want nil", err.Error())
+	}
+
+	_, resp, err := c.Issues.Get(ctx, "o", "r", created.GetNumber())
+
+	if err != nil {
+		t.Fatalf("get issue err is %s, want nil", err.Error())
+	}
+
+	etag := resp.Header.Get("ETag")
+
+	if etag == "" {
+		t.Fatal("ETag header is empty, want a value")
+	}
+
+	req, err := c.NewRequest("GET", fmt.Sprintf("repos/o/r/issues/%d", created.GetNumber()), nil)
+
+	if err != nil {
+		t.Fatalf("could not build request: %s", err.Error())
+	}
+
+	req.Header.Set("If-None-Match", etag)
+
+	// A 304 is still a non-2xx status, so go-github's CheckResponse wraps
+	// it in an error rather than returning nil for the conditional hit.
+	condResp, err := c.Do(ctx, req, nil)
+
+	if err == nil {
+		t.Fatal("conditional get err is nil, want a 304 response")
+	}
+
+	if condResp.StatusCode != 304 {
+		t.Errorf("status is %d, want 304", condResp.StatusCode)
+	}
+
+	if _, ok := err.(*github.ErrorResponse); !ok {
+		t.Errorf("err is %T, want *github.ErrorResponse", err)
+	}
+}
+
+func TestFakeGitHubBackendUpdateIssueIfMatchConflict(t *testing.T) {
+	backend := NewFakeGitHubBackend()
+	c := github.NewClient(backend.NewMockedHTTPClient())
+	ctx := context.Background()
+
+	created, _, err := c.Issues.Create(ctx, "o", "r", &github.IssueRequest{Title: github.String("first")})
+
+	if err != nil {
+		t.Fatalf("create issue err is %s, want nil", err.Error())
+	}
+
+	req, err := c.NewRequest("PATCH", fmt.Sprintf("repos/o/r/issues/%d", created.GetNumber()), &github.IssueRequest{
+		Title: github.String("stale update"),
+	})
+
+	if err != nil {
+		t.Fatalf("could not build request: %s", err.Error())
+	}
+
+	req.Header.Set("If-Match", `"stale-etag"`)
+
+	resp, err := c.Do(ctx, req, nil)
+
+	if err == nil {
+		t.Fatal("update with a stale If-Match err is nil, want a 412 response")
+	}
+
+	if resp.StatusCode != 412 {
+		t.Errorf("status is %d, want 412", resp.StatusCode)
+	}
+
+	unchanged, _, err := c.Issues.Get(ctx, "o", "r", created.GetNumber())
+
+	if err != nil {
+		t.Fatalf("get issue err is %s, want nil", err.Error())
+	}
+
+	if unchanged.GetTitle() != "first" {
+		t.Errorf("issue title is %s after a rejected update, want it unchanged at 'first'", unchanged.GetTitle())
+	}
+}
@@ -0,0 +1,42 @@
+package fake
+
+import (
+	"sync"
+
+	"github.com/google/go-github/v37/github"
+)
+
+type issueKey struct {
+	repoKey
+	number int
+}
+
+type commentStore struct {
+	mu      sync.Mutex
+	byIssue map[issueKey][]*github.IssueComment
+	nextID  int64
+}
+
+func newCommentStore() *commentStore {
+	return &commentStore{byIssue: map[issueKey][]*github.IssueComment{}}
+}
+
+func (s *commentStore) create(owner, repo string, number int, comment *github.IssueComment) *github.IssueComment {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.nextID++
+	comment.ID = github.Int64(s.nextID)
+
+	key := issueKey{repoKey{owner, repo}, number}
+	s.byIssue[key] = append(s.byIssue[key], comment)
+
+	return comment
+}
+
+func (s *commentStore) list(owner, repo string, number int) []*github.IssueComment {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.byIssue[issueKey{repoKey{owner, repo}, number}]
+}
@@ -0,0 +1,208 @@
+package fake
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/antonlisovenko/go-github-mock/src/mock"
+	"github.com/google/go-github/v37/github"
+	"github.com/gorilla/mux"
+)
+
+const issuesPerPage = 30
+
+func (b *Backend) listIssues(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	var labels []string
+	if l := r.URL.Query().Get("labels"); l != "" {
+		labels = strings.Split(l, ",")
+	}
+
+	issues := b.issues.list(vars["owner"], vars["repo"], r.URL.Query().Get("state"), labels)
+
+	(&mock.PaginatedReponseHandler{ResponsePages: paginateIssues(issues)}).ServeHTTP(w, r)
+}
+
+func paginateIssues(issues []*github.Issue) [][]byte {
+	if len(issues) == 0 {
+		return [][]byte{mock.MustMarshal([]*github.Issue{})}
+	}
+
+	var pages [][]byte
+	for i := 0; i < len(issues); i += issuesPerPage {
+		end := i + issuesPerPage
+		if end > len(issues) {
+			end = len(issues)
+		}
+
+		pages = append(pages, mock.MustMarshal(issues[i:end]))
+	}
+
+	return pages
+}
+
+func (b *Backend) createIssue(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	owner, repo := vars["owner"], vars["repo"]
+
+	var body github.IssueRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		mock.WriteError(w, http.StatusBadRequest, fmt.Sprintf("could not decode issue body: %s", err.Error()))
+		return
+	}
+
+	b.repos.getOrCreate(owner, repo)
+
+	issue := &github.Issue{Title: body.Title, Body: body.Body}
+	if body.Labels != nil {
+		issue.Labels = b.resolveLabels(owner, repo, *body.Labels)
+	}
+
+	issue = b.issues.create(owner, repo, issue)
+	_, etag, _ := b.issues.get(owner, repo, issue.GetNumber())
+
+	w.Header().Set("ETag", etag)
+	w.WriteHeader(http.StatusCreated)
+	w.Write(mock.MustMarshal(issue))
+}
+
+func (b *Backend) getIssue(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	owner, repo := vars["owner"], vars["repo"]
+
+	number, err := strconv.Atoi(vars["issue_number"])
+	if err != nil {
+		mock.WriteError(w, http.StatusBadRequest, "invalid issue_number")
+		return
+	}
+
+	issue, etag, ok := b.issues.get(owner, repo, number)
+	if !ok {
+		mock.WriteError(w, http.StatusNotFound, fmt.Sprintf("issue %s/%s#%d not found", owner, repo, number))
+		return
+	}
+
+	if inm := r.Header.Get("If-None-Match"); inm != "" && inm == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("ETag", etag)
+	w.Write(mock.MustMarshal(issue))
+}
+
+func (b *Backend) updateIssue(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	owner, repo := vars["owner"], vars["repo"]
+
+	number, err := strconv.Atoi(vars["issue_number"])
+	if err != nil {
+		mock.WriteError(w, http.StatusBadRequest, "invalid issue_number")
+		return
+	}
+
+	var body github.IssueRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		mock.WriteError(w, http.StatusBadRequest, fmt.Sprintf("could not decode issue body: %s", err.Error()))
+		return
+	}
+
+	issue, etag, ok, conflict := b.issues.update(owner, repo, number, r.Header.Get("If-Match"), func(issue *github.Issue) {
+		if body.Title != nil {
+			issue.Title = body.Title
+		}
+		if body.Body != nil {
+			issue.Body = body.Body
+		}
+		if body.State != nil {
+			issue.State = body.State
+		}
+		if body.Labels != nil {
+			issue.Labels = b.resolveLabels(owner, repo, *body.Labels)
+		}
+	})
+
+	if !ok {
+		mock.WriteError(w, http.StatusNotFound, fmt.Sprintf("issue %s/%s#%d not found", owner, repo, number))
+		return
+	}
+
+	if conflict {
+		mock.WriteError(w, http.StatusPreconditionFailed, "ETag mismatch, issue was modified")
+		return
+	}
+
+	w.Header().Set("ETag", etag)
+	w.Write(mock.MustMarshal(issue))
+}
+
+func (b *Backend) resolveLabels(owner, repo string, names []string) []*github.Label {
+	labels := make([]*github.Label, 0, len(names))
+	for _, name := range names {
+		label, ok := b.labels.get(owner, repo, name)
+		if !ok {
+			label = b.labels.create(owner, repo, &github.Label{Name: github.String(name)})
+		}
+		labels = append(labels, label)
+	}
+
+	return labels
+}
+
+func (b *Backend) listComments(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	number, err := strconv.Atoi(vars["issue_number"])
+	if err != nil {
+		mock.WriteError(w, http.StatusBadRequest, "invalid issue_number")
+		return
+	}
+
+	w.Write(mock.MustMarshal(b.comments.list(vars["owner"], vars["repo"], number)))
+}
+
+func (b *Backend) createComment(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	owner, repo := vars["owner"], vars["repo"]
+
+	number, err := strconv.Atoi(vars["issue_number"])
+	if err != nil {
+		mock.WriteError(w, http.StatusBadRequest, "invalid issue_number")
+		return
+	}
+
+	var body github.IssueComment
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		mock.WriteError(w, http.StatusBadRequest, fmt.Sprintf("could not decode comment body: %s", err.Error()))
+		return
+	}
+
+	comment := b.comments.create(owner, repo, number, &github.IssueComment{Body: body.Body})
+
+	w.WriteHeader(http.StatusCreated)
+	w.Write(mock.MustMarshal(comment))
+}
+
+func (b *Backend) listLabels(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	w.Write(mock.MustMarshal(b.labels.list(vars["owner"], vars["repo"])))
+}
+
+func (b *Backend) createLabel(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	var label github.Label
+	if err := json.NewDecoder(r.Body).Decode(&label); err != nil {
+		mock.WriteError(w, http.StatusBadRequest, fmt.Sprintf("could not decode label body: %s", err.Error()))
+		return
+	}
+
+	created := b.labels.create(vars["owner"], vars["repo"], &label)
+
+	w.WriteHeader(http.StatusCreated)
+	w.Write(mock.MustMarshal(created))
+}
@@ -0,0 +1,148 @@
+package fake
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/google/go-github/v37/github"
+)
+
+// issueStore holds every issue ever created against the backend, grouped by
+// repository, and allocates issue numbers the way GitHub does: a
+// per-repository counter that never reuses a number.
+type issueStore struct {
+	mu         sync.Mutex
+	byRepo     map[repoKey]map[int]*github.Issue
+	etags      map[repoKey]map[int]string
+	nextNumber map[repoKey]int
+}
+
+func newIssueStore() *issueStore {
+	return &issueStore{
+		byRepo:     map[repoKey]map[int]*github.Issue{},
+		etags:      map[repoKey]map[int]string{},
+		nextNumber: map[repoKey]int{},
+	}
+}
+
+// create allocates the next issue number for owner/repo, persists issue
+// under it, and returns the stored copy.
+func (s *issueStore) create(owner, repo string, issue *github.Issue) *github.Issue {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := repoKey{owner, repo}
+
+	s.nextNumber[key]++
+	issue.Number = github.Int(s.nextNumber[key])
+
+	if issue.State == nil {
+		issue.State = github.String("open")
+	}
+
+	if s.byRepo[key] == nil {
+		s.byRepo[key] = map[int]*github.Issue{}
+		s.etags[key] = map[int]string{}
+	}
+
+	s.byRepo[key][issue.GetNumber()] = issue
+	s.etags[key][issue.GetNumber()] = newETag()
+
+	return issue
+}
+
+// get returns the issue at owner/repo#number along with its current ETag.
+func (s *issueStore) get(owner, repo string, number int) (*github.Issue, string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := repoKey{owner, repo}
+
+	issue, ok := s.byRepo[key][number]
+	if !ok {
+		return nil, "", false
+	}
+
+	return issue, s.etags[key][number], true
+}
+
+// update applies mutate to the stored issue at owner/repo#number and bumps
+// its ETag, returning the updated issue. If ifMatch is non-empty, the
+// precondition check against the issue's current ETag and the mutation
+// happen under the same critical section, so two concurrent updates can't
+// both pass the check before either applies -- a mismatch is reported via
+// conflict rather than applying mutate.
+func (s *issueStore) update(owner, repo string, number int, ifMatch string, mutate func(*github.Issue)) (issue *github.Issue, etag string, ok bool, conflict bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := repoKey{owner, repo}
+
+	issue, ok = s.byRepo[key][number]
+	if !ok {
+		return nil, "", false, false
+	}
+
+	if ifMatch != "" && ifMatch != s.etags[key][number] {
+		return issue, s.etags[key][number], true, true
+	}
+
+	mutate(issue)
+
+	etag = newETag()
+	s.etags[key][number] = etag
+
+	return issue, etag, true, false
+}
+
+// list returns every issue for owner/repo matching state ("open", "closed",
+// "all", or "" meaning "open") and, if non-empty, carrying every label in
+// labels, ordered by issue number.
+func (s *issueStore) list(owner, repo, state string, labels []string) []*github.Issue {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := repoKey{owner, repo}
+
+	numbers := make([]int, 0, len(s.byRepo[key]))
+	for n := range s.byRepo[key] {
+		numbers = append(numbers, n)
+	}
+	sort.Ints(numbers)
+
+	if state == "" {
+		state = "open"
+	}
+
+	var matched []*github.Issue
+	for _, n := range numbers {
+		issue := s.byRepo[key][n]
+
+		if state != "all" && issue.GetState() != state {
+			continue
+		}
+
+		if len(labels) > 0 && !issueHasAllLabels(issue, labels) {
+			continue
+		}
+
+		matched = append(matched, issue)
+	}
+
+	return matched
+}
+
+func issueHasAllLabels(issue *github.Issue, want []string) bool {
+	have := map[string]bool{}
+	for _, l := range issue.Labels {
+		have[l.GetName()] = true
+	}
+
+	for _, w := range want {
+		if !have[w] {
+			return false
+		}
+	}
+
+	return true
+}
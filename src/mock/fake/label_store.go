@@ -0,0 +1,61 @@
+package fake
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/google/go-github/v37/github"
+)
+
+type labelStore struct {
+	mu   sync.Mutex
+	data map[repoKey]map[string]*github.Label
+}
+
+func newLabelStore() *labelStore {
+	return &labelStore{data: map[repoKey]map[string]*github.Label{}}
+}
+
+func (s *labelStore) create(owner, repo string, label *github.Label) *github.Label {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := repoKey{owner, repo}
+	if s.data[key] == nil {
+		s.data[key] = map[string]*github.Label{}
+	}
+
+	s.data[key][label.GetName()] = label
+
+	return label
+}
+
+func (s *labelStore) get(owner, repo, name string) (*github.Label, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	label, ok := s.data[repoKey{owner, repo}][name]
+
+	return label, ok
+}
+
+// list returns every label for owner/repo, ordered by name.
+func (s *labelStore) list(owner, repo string) []*github.Label {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := repoKey{owner, repo}
+
+	names := make([]string, 0, len(s.data[key]))
+	for n := range s.data[key] {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	labels := make([]*github.Label, 0, len(names))
+	for _, n := range names {
+		labels = append(labels, s.data[key][n])
+	}
+
+	return labels
+}
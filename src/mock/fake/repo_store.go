@@ -0,0 +1,38 @@
+package fake
+
+import "sync"
+
+// repoRecord is the in-memory representation of a repository. It currently
+// exists only as an anchor other stores key off of; fields will grow as the
+// fake backend grows repository-level behavior (e.g. default branch).
+type repoRecord struct {
+	owner string
+	repo  string
+}
+
+type repoStore struct {
+	mu   sync.Mutex
+	data map[repoKey]*repoRecord
+}
+
+func newRepoStore() *repoStore {
+	return &repoStore{data: map[repoKey]*repoRecord{}}
+}
+
+// getOrCreate returns the repoRecord for owner/repo, creating it if this is
+// the first time it's been seen.
+func (s *repoStore) getOrCreate(owner, repo string) *repoRecord {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := repoKey{owner, repo}
+
+	if r, ok := s.data[key]; ok {
+		return r
+	}
+
+	r := &repoRecord{owner: owner, repo: repo}
+	s.data[key] = r
+
+	return r
+}
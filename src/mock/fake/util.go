@@ -0,0 +1,21 @@
+package fake
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// repoKey identifies a repository by owner/name, the key every per-resource
+// store indexes on.
+type repoKey struct {
+	owner string
+	repo  string
+}
+
+var etagSeq int64
+
+// newETag allocates a new, process-unique ETag, used to support
+// `If-Match`/`If-None-Match` on issues as they're created and mutated.
+func newETag() string {
+	return fmt.Sprintf(`"%d"`, atomic.AddInt64(&etagSeq, 1))
+}
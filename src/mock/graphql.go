@@ -0,0 +1,173 @@
+package mock
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+
+	"github.com/gorilla/mux"
+)
+
+// GraphQLPattern is the single endpoint GitHub's GraphQL API is served on.
+const GraphQLPattern = "/graphql"
+
+const graphQLRouteName = "go-github-mock-graphql"
+
+// graphQLRequestBody models the JSON body `githubv4`/`shurcooL/graphql`
+// clients send to the GraphQL endpoint.
+type graphQLRequestBody struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables"`
+}
+
+// GraphQLVariableMatcher reports whether the GraphQL variables sent with a
+// request satisfy some test-defined condition.
+type GraphQLVariableMatcher func(variables map[string]interface{}) bool
+
+// MatchGraphQLVariable returns a GraphQLVariableMatcher that matches when
+// `variables[key] == want`, e.g. `MatchGraphQLVariable("owner", "foo")`.
+// want is compared against the decoded request variable -- which, like
+// every value `encoding/json` decodes into `interface{}`, represents all
+// JSON numbers as `float64` -- by normalizing want through the same
+// JSON round-trip first, so `MatchGraphQLVariable("count", 5)` matches a
+// request variable sent as the JSON number `5`. Slices and maps compare by
+// deep equality rather than `==`, which would panic on them.
+func MatchGraphQLVariable(key string, want interface{}) GraphQLVariableMatcher {
+	return func(variables map[string]interface{}) bool {
+		got, ok := variables[key]
+		if !ok {
+			return false
+		}
+
+		return graphQLVariableEqual(got, want)
+	}
+}
+
+func graphQLVariableEqual(got, want interface{}) bool {
+	wantBytes, err := json.Marshal(want)
+	if err != nil {
+		return false
+	}
+
+	var normalizedWant interface{}
+	if json.Unmarshal(wantBytes, &normalizedWant) != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(got, normalizedWant)
+}
+
+// graphQLHandler pairs a query matcher (and optional variable matchers) with
+// the response it should produce.
+type graphQLHandler struct {
+	queryRegexp *regexp.Regexp
+	varMatchers []GraphQLVariableMatcher
+	response    []byte
+}
+
+func (h *graphQLHandler) matches(body graphQLRequestBody) bool {
+	if !h.queryRegexp.MatchString(body.Query) {
+		return false
+	}
+
+	for _, vm := range h.varMatchers {
+		if !vm(body.Variables) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// GraphQLMatcher is the registry of GraphQL handlers registered against a
+// mocked backend. Incoming `POST /graphql` requests are dispatched to the
+// first registered handler whose query (and variable matchers, if any)
+// match the request body.
+type GraphQLMatcher struct {
+	handlers []*graphQLHandler
+}
+
+// ServeHTTP implementation of `http.Handler`
+func (g *GraphQLMatcher) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var body graphQLRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		WriteError(
+			w,
+			http.StatusBadRequest,
+			fmt.Sprintf("go-github-mock: could not decode GraphQL request body: %s", err.Error()),
+		)
+		return
+	}
+
+	for _, h := range g.handlers {
+		if h.matches(body) {
+			w.Write(h.response)
+			return
+		}
+	}
+
+	WriteError(
+		w,
+		http.StatusNotFound,
+		fmt.Sprintf("go-github-mock: no GraphQL mock matches query: %s", body.Query),
+	)
+}
+
+// graphQLMatcherFor returns the GraphQLMatcher registered on router,
+// creating and attaching one on its first call.
+func graphQLMatcherFor(router *mux.Router) *GraphQLMatcher {
+	if route := router.Get(graphQLRouteName); route != nil {
+		return route.GetHandler().(*GraphQLMatcher)
+	}
+
+	matcher := &GraphQLMatcher{}
+	router.
+		Handle(GraphQLPattern, matcher).
+		Methods(http.MethodPost).
+		Name(graphQLRouteName)
+
+	return matcher
+}
+
+// WithGraphQLQueryMatch registers a GraphQL mock that responds with
+// `response` whenever the incoming `POST /graphql` request's `query` field
+// matches `queryRegexp`.
+func WithGraphQLQueryMatch(queryRegexp *regexp.Regexp, response []byte) MockBackendOption {
+	return WithGraphQLMatch(queryRegexp, nil, response)
+}
+
+// WithGraphQLMatch registers a GraphQL mock that responds with `response`
+// whenever the incoming request's `query` field matches `queryRegexp` and
+// every matcher in `varMatchers` (if any) succeeds against the request's
+// `variables`.
+func WithGraphQLMatch(
+	queryRegexp *regexp.Regexp,
+	varMatchers []GraphQLVariableMatcher,
+	response []byte,
+) MockBackendOption {
+	return func(router *mux.Router) {
+		matcher := graphQLMatcherFor(router)
+		matcher.handlers = append(matcher.handlers, &graphQLHandler{
+			queryRegexp: queryRegexp,
+			varMatchers: varMatchers,
+			response:    response,
+		})
+	}
+}
+
+// GraphQLErrorItem mirrors a single entry of the `errors` array returned
+// alongside (or instead of) `data` by GitHub's GraphQL API.
+type GraphQLErrorItem struct {
+	Message string        `json:"message"`
+	Path    []interface{} `json:"path,omitempty"`
+}
+
+// MustMarshalGraphQLErrors wraps errs in the `{"errors": [...]}` envelope
+// GraphQL clients expect and marshals it, panicking on failure.
+func MustMarshalGraphQLErrors(errs ...GraphQLErrorItem) []byte {
+	return MustMarshal(struct {
+		Errors []GraphQLErrorItem `json:"errors"`
+	}{Errors: errs})
+}
@@ -0,0 +1,175 @@
+package mock
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"testing"
+)
+
+func doGraphQLRequest(t *testing.T, c *http.Client, query string, variables map[string]interface{}) []byte {
+	t.Helper()
+
+	body, err := json.Marshal(graphQLRequestBody{
+		Query:     query,
+		Variables: variables,
+	})
+
+	if err != nil {
+		t.Fatalf("could not marshal GraphQL request: %s", err.Error())
+	}
+
+	resp, err := c.Post("http://mock.localhost/graphql", "application/json", bytes.NewReader(body))
+
+	if err != nil {
+		t.Fatalf("could not perform GraphQL request: %s", err.Error())
+	}
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+
+	if err != nil {
+		t.Fatalf("could not read GraphQL response: %s", err.Error())
+	}
+
+	return respBody
+}
+
+func TestWithGraphQLQueryMatch(t *testing.T) {
+	mockedHTTPClient := NewMockedHTTPClient(
+		WithGraphQLQueryMatch(
+			regexp.MustCompile(`query\s*\{\s*viewer\s*\{\s*login`),
+			MustMarshal(map[string]interface{}{
+				"data": map[string]interface{}{
+					"viewer": map[string]interface{}{
+						"login": "foobar",
+					},
+				},
+			}),
+		),
+	)
+
+	respBody := doGraphQLRequest(t, mockedHTTPClient, `query { viewer { login } }`, nil)
+
+	var parsed struct {
+		Data struct {
+			Viewer struct {
+				Login string `json:"login"`
+			} `json:"viewer"`
+		} `json:"data"`
+	}
+
+	if err := json.Unmarshal(respBody, &parsed); err != nil {
+		t.Fatalf("could not unmarshal response: %s", err.Error())
+	}
+
+	if parsed.Data.Viewer.Login != "foobar" {
+		t.Errorf("viewer.login is %s, want foobar", parsed.Data.Viewer.Login)
+	}
+}
+
+func TestWithGraphQLMatchVariables(t *testing.T) {
+	mockedHTTPClient := NewMockedHTTPClient(
+		WithGraphQLMatch(
+			regexp.MustCompile(`repository`),
+			[]GraphQLVariableMatcher{MatchGraphQLVariable("owner", "foo")},
+			MustMarshal(map[string]interface{}{
+				"data": map[string]interface{}{
+					"repository": map[string]interface{}{"name": "matched-foo"},
+				},
+			}),
+		),
+		WithGraphQLMatch(
+			regexp.MustCompile(`repository`),
+			[]GraphQLVariableMatcher{MatchGraphQLVariable("owner", "bar")},
+			MustMarshal(map[string]interface{}{
+				"data": map[string]interface{}{
+					"repository": map[string]interface{}{"name": "matched-bar"},
+				},
+			}),
+		),
+	)
+
+	respBody := doGraphQLRequest(
+		t,
+		mockedHTTPClient,
+		`query($owner: String!) { repository(owner: $owner, name: "baz") { name } }`,
+		map[string]interface{}{"owner": "bar"},
+	)
+
+	if !bytes.Contains(respBody, []byte("matched-bar")) {
+		t.Errorf("response body is %s, want it to contain matched-bar", respBody)
+	}
+}
+
+func TestMatchGraphQLVariableNumericAndSlice(t *testing.T) {
+	mockedHTTPClient := NewMockedHTTPClient(
+		WithGraphQLMatch(
+			regexp.MustCompile(`search`),
+			[]GraphQLVariableMatcher{
+				MatchGraphQLVariable("count", 5),
+				MatchGraphQLVariable("labels", []string{"bug", "P1"}),
+			},
+			MustMarshal(map[string]interface{}{
+				"data": map[string]interface{}{"search": map[string]interface{}{"issueCount": 5}},
+			}),
+		),
+	)
+
+	respBody := doGraphQLRequest(
+		t,
+		mockedHTTPClient,
+		`query($count: Int!, $labels: [String!]) { search(first: $count, labels: $labels) { issueCount } }`,
+		map[string]interface{}{
+			"count":  5,
+			"labels": []string{"bug", "P1"},
+		},
+	)
+
+	if !bytes.Contains(respBody, []byte(`"issueCount":5`)) {
+		t.Errorf("response body is %s, want it to contain issueCount:5", respBody)
+	}
+}
+
+func TestWithGraphQLQueryMatchNotFound(t *testing.T) {
+	mockedHTTPClient := NewMockedHTTPClient(
+		WithGraphQLQueryMatch(
+			regexp.MustCompile(`viewer`),
+			MustMarshal(map[string]interface{}{"data": map[string]interface{}{}}),
+		),
+	)
+
+	respBody := doGraphQLRequest(t, mockedHTTPClient, `query { organization { name } }`, nil)
+
+	if !bytes.Contains(respBody, []byte("no GraphQL mock matches query")) {
+		t.Errorf("response body is %s, want an unmatched query error", respBody)
+	}
+}
+
+func TestMustMarshalGraphQLErrors(t *testing.T) {
+	mockedHTTPClient := NewMockedHTTPClient(
+		WithGraphQLQueryMatch(
+			regexp.MustCompile(`createIssue`),
+			MustMarshalGraphQLErrors(GraphQLErrorItem{
+				Message: "Could not resolve to a Repository",
+				Path:    []interface{}{"createIssue"},
+			}),
+		),
+	)
+
+	respBody := doGraphQLRequest(t, mockedHTTPClient, `mutation { createIssue(input: {}) { issue { id } } }`, nil)
+
+	var parsed struct {
+		Errors []GraphQLErrorItem `json:"errors"`
+	}
+
+	if err := json.Unmarshal(respBody, &parsed); err != nil {
+		t.Fatalf("could not unmarshal response: %s", err.Error())
+	}
+
+	if len(parsed.Errors) != 1 || parsed.Errors[0].Message != "Could not resolve to a Repository" {
+		t.Errorf("errors is %+v, want a single 'Could not resolve to a Repository' error", parsed.Errors)
+	}
+}
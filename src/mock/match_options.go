@@ -0,0 +1,220 @@
+package mock
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// MatchOptions describes constraints an inbound request must satisfy for a
+// WithRequestMatchOptions mock to be selected. A nil/zero field is not
+// checked. When several mocks are registered for the same EndpointPattern,
+// the router picks the first one whose MatchOptions fully match.
+type MatchOptions struct {
+	// Body, when set, is deep-compared against the request's JSON body.
+	Body interface{}
+	// BodyJSONPath, when set, maps a dotted JSON path (e.g. "labels.0.name")
+	// in the request's JSON body to the value it must hold.
+	BodyJSONPath map[string]interface{}
+	// Query, when set, must be a subset of the request's query parameters.
+	Query url.Values
+	// Headers, when set, must be a subset of the request's headers.
+	Headers http.Header
+	// PathVars, when set, must be a subset of the path variables
+	// `gorilla/mux` resolved for the request, e.g. `{"owner": "foo"}`.
+	PathVars map[string]string
+}
+
+// failedMatchers returns the name of every constraint in opts that r (with
+// its already-decoded JSON body) does not satisfy. An empty result means r
+// is a full match.
+func (opts MatchOptions) failedMatchers(r *http.Request, rawBody []byte, decodedBody interface{}) []string {
+	var failed []string
+
+	if opts.Body != nil && !jsonDeepEqual(opts.Body, rawBody) {
+		failed = append(failed, "Body")
+	}
+
+	for path, want := range opts.BodyJSONPath {
+		got, ok := jsonPathLookup(decodedBody, path)
+		if !ok || !jsonDeepEqual(want, MustMarshal(got)) {
+			failed = append(failed, fmt.Sprintf("BodyJSONPath[%s]", path))
+		}
+	}
+
+	if !valuesContain(r.URL.Query(), opts.Query) {
+		failed = append(failed, "Query")
+	}
+
+	if !headerContains(r.Header, opts.Headers) {
+		failed = append(failed, "Headers")
+	}
+
+	vars := mux.Vars(r)
+	for k, want := range opts.PathVars {
+		if vars[k] != want {
+			failed = append(failed, fmt.Sprintf("PathVars[%s]", k))
+		}
+	}
+
+	return failed
+}
+
+func jsonDeepEqual(want interface{}, rawBody []byte) bool {
+	wantBytes, err := json.Marshal(want)
+	if err != nil {
+		return false
+	}
+
+	var wantValue, gotValue interface{}
+	if json.Unmarshal(wantBytes, &wantValue) != nil {
+		return false
+	}
+	if json.Unmarshal(rawBody, &gotValue) != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(wantValue, gotValue)
+}
+
+// jsonPathLookup resolves a dotted path (object keys and array indices,
+// e.g. "labels.0.name") against a value produced by json.Unmarshal into an
+// interface{}.
+func jsonPathLookup(v interface{}, path string) (interface{}, bool) {
+	cur := v
+	for _, part := range strings.Split(path, ".") {
+		switch node := cur.(type) {
+		case map[string]interface{}:
+			val, ok := node[part]
+			if !ok {
+				return nil, false
+			}
+			cur = val
+		case []interface{}:
+			idx, err := strconv.Atoi(part)
+			if err != nil || idx < 0 || idx >= len(node) {
+				return nil, false
+			}
+			cur = node[idx]
+		default:
+			return nil, false
+		}
+	}
+
+	return cur, true
+}
+
+func valuesContain(got, want url.Values) bool {
+	for key, wantValues := range want {
+		gotValues := got[key]
+		for _, wantValue := range wantValues {
+			found := false
+			for _, gotValue := range gotValues {
+				if gotValue == wantValue {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func headerContains(got, want http.Header) bool {
+	for key := range want {
+		if got.Get(key) != want.Get(key) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// matchOptionsCandidate pairs a MatchOptions constraint with the handler
+// that serves requests satisfying it.
+type matchOptionsCandidate struct {
+	options MatchOptions
+	handler http.Handler
+}
+
+// matchOptionsRouter dispatches a request to the first registered candidate
+// whose MatchOptions match, returning 404 with a diagnostic body otherwise.
+type matchOptionsRouter struct {
+	candidates []*matchOptionsCandidate
+}
+
+// ServeHTTP implementation of `http.Handler`
+func (m *matchOptionsRouter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	rawBody, _ := ioutil.ReadAll(r.Body)
+	r.Body = ioutil.NopCloser(bytes.NewReader(rawBody))
+
+	var decodedBody interface{}
+	_ = json.Unmarshal(rawBody, &decodedBody)
+
+	var diagnostics []string
+	for i, c := range m.candidates {
+		failed := c.options.failedMatchers(r, rawBody, decodedBody)
+		if len(failed) == 0 {
+			c.handler.ServeHTTP(w, r)
+			return
+		}
+
+		diagnostics = append(diagnostics, fmt.Sprintf("candidate %d failed: %s", i, strings.Join(failed, ", ")))
+	}
+
+	WriteError(
+		w,
+		http.StatusNotFound,
+		fmt.Sprintf(
+			"go-github-mock: no mock matched %s %s\n%s",
+			r.Method, r.URL.Path, strings.Join(diagnostics, "\n"),
+		),
+	)
+}
+
+func matchOptionsRouterFor(router *mux.Router, pattern EndpointPattern) *matchOptionsRouter {
+	name := "go-github-mock-match-options:" + pattern.Method + " " + pattern.Pattern
+
+	if route := router.Get(name); route != nil {
+		return route.GetHandler().(*matchOptionsRouter)
+	}
+
+	mr := &matchOptionsRouter{}
+	router.Handle(pattern.Pattern, mr).Methods(pattern.Method).Name(name)
+
+	return mr
+}
+
+// WithRequestMatchOptions registers a mock for pattern that is only served
+// when the inbound request satisfies options. When multiple mocks are
+// registered for the same pattern via WithRequestMatchOptions, the first
+// whose options fully match wins; if none match, the router responds with
+// 404 and a diagnostic body listing which matchers failed for each
+// candidate.
+func WithRequestMatchOptions(
+	pattern EndpointPattern,
+	options MatchOptions,
+	responses [][]byte,
+) MockBackendOption {
+	handler := &FIFOReponseHandler{Responses: responses}
+
+	return func(router *mux.Router) {
+		mr := matchOptionsRouterFor(router, pattern)
+		mr.candidates = append(mr.candidates, &matchOptionsCandidate{
+			options: options,
+			handler: handler,
+		})
+	}
+}
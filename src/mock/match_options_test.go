@@ -0,0 +1,91 @@
+package mock
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-github/v37/github"
+)
+
+func TestWithRequestMatchOptionsBody(t *testing.T) {
+	mockedHTTPClient := NewMockedHTTPClient(
+		WithRequestMatchOptions(
+			PostReposIssuesByOwnerByRepo,
+			MatchOptions{BodyJSONPath: map[string]interface{}{"title": "bug report"}},
+			[][]byte{MustMarshal(github.Issue{Number: github.Int(1), Title: github.String("bug report")})},
+		),
+		WithRequestMatchOptions(
+			PostReposIssuesByOwnerByRepo,
+			MatchOptions{BodyJSONPath: map[string]interface{}{"title": "feature request"}},
+			[][]byte{MustMarshal(github.Issue{Number: github.Int(2), Title: github.String("feature request")})},
+		),
+	)
+
+	c := github.NewClient(mockedHTTPClient)
+	ctx := context.Background()
+
+	issue, _, err := c.Issues.Create(ctx, "o", "r", &github.IssueRequest{Title: github.String("feature request")})
+
+	if err != nil {
+		t.Fatalf("err is %s, want nil", err.Error())
+	}
+
+	if issue.GetNumber() != 2 {
+		t.Errorf("issue number is %d, want 2", issue.GetNumber())
+	}
+}
+
+func TestWithRequestMatchOptionsHeaders(t *testing.T) {
+	mockedHTTPClient := NewMockedHTTPClient(
+		WithRequestMatchOptions(
+			GetUsersByUsername,
+			MatchOptions{Headers: http.Header{"Accept": []string{"application/vnd.github.v3+json"}}},
+			[][]byte{MustMarshal(github.User{Name: github.String("foobar")})},
+		),
+	)
+
+	c := github.NewClient(mockedHTTPClient)
+
+	user, _, err := c.Users.Get(context.Background(), "someUser")
+
+	if err != nil {
+		t.Fatalf("err is %s, want nil", err.Error())
+	}
+
+	if user.GetName() != "foobar" {
+		t.Errorf("user name is %s, want foobar", user.GetName())
+	}
+}
+
+func TestWithRequestMatchOptionsNoneMatch(t *testing.T) {
+	mockedHTTPClient := NewMockedHTTPClient(
+		WithRequestMatchOptions(
+			PostReposIssuesByOwnerByRepo,
+			MatchOptions{BodyJSONPath: map[string]interface{}{"title": "only this title matches"}},
+			[][]byte{MustMarshal(github.Issue{Number: github.Int(1)})},
+		),
+	)
+
+	c := github.NewClient(mockedHTTPClient)
+
+	_, resp, err := c.Issues.Create(context.Background(), "o", "r", &github.IssueRequest{Title: github.String("something else")})
+
+	if err == nil {
+		t.Fatal("err is nil, want a 404 response")
+	}
+
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("status is %d, want 404", resp.StatusCode)
+	}
+
+	ghErr, ok := err.(*github.ErrorResponse)
+	if !ok {
+		t.Fatal("couldn't cast err to *github.ErrorResponse")
+	}
+
+	if !bytes.Contains([]byte(ghErr.Message), []byte("failed:")) {
+		t.Errorf("error message is %s, want a diagnostic listing failed matchers", ghErr.Message)
+	}
+}
@@ -0,0 +1,126 @@
+package mock
+
+import (
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+type rateLimitErrorBody struct {
+	Message          string  `json:"message"`
+	DocumentationURL string  `json:"documentation_url"`
+	RetryAfter       float64 `json:"retry_after,omitempty"`
+}
+
+// WithRateLimit makes every response to a request that matches a registered
+// mock carry the standard GitHub `X-RateLimit-*` headers, computed from
+// limit/remaining as requests come in, and makes the backend return `403`
+// with the documented rate-limit-exceeded error body once the budget is
+// exhausted -- letting tests exercise `go-github`'s built-in
+// `RateLimitError` handling. Requests that match no registered mock reach
+// the NotFoundHandler without these headers and are not counted.
+func WithRateLimit(limit, remaining int, resetAt time.Time) MockBackendOption {
+	return func(router *mux.Router) {
+		var served int32
+
+		router.Use(func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				count := int(atomic.AddInt32(&served, 1))
+
+				left := remaining - count
+				if left < 0 {
+					left = 0
+				}
+
+				w.Header().Set("X-RateLimit-Limit", strconv.Itoa(limit))
+				w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(left))
+				w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(resetAt.Unix(), 10))
+
+				if count > remaining {
+					writeRateLimitError(w, http.StatusForbidden, rateLimitErrorBody{
+						Message:          "API rate limit exceeded for testing.",
+						DocumentationURL: "https://docs.github.com/rest/overview/resources-in-the-rest-api#rate-limiting",
+					})
+					return
+				}
+
+				next.ServeHTTP(w, r)
+			})
+		})
+	}
+}
+
+// WithSecondaryRateLimit makes the mocked backend respond to every request
+// that matches a registered mock with GitHub's `403` secondary-rate-limit
+// error, carrying a `Retry-After` header, so `go-github`'s retry/backoff
+// code paths can be exercised without waiting out a real rate limit window.
+// Requests that match no registered mock still get the NotFoundHandler's 404.
+func WithSecondaryRateLimit(retryAfter time.Duration) MockBackendOption {
+	return func(router *mux.Router) {
+		router.Use(func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Retry-After", strconv.Itoa(int(retryAfter.Seconds())))
+				writeRateLimitError(w, http.StatusForbidden, rateLimitErrorBody{
+					Message: "You have exceeded a secondary rate limit and have been temporarily blocked from content creation. Please retry your request again later.",
+					// go-github's CheckResponse only upgrades a 403 into an
+					// *AbuseRateLimitError when DocumentationURL ends in
+					// this exact suffix.
+					DocumentationURL: "https://docs.github.com/rest/overview/resources-in-the-rest-api#abuse-rate-limits",
+				})
+			})
+		})
+	}
+}
+
+// WithAbuseDetection makes the mocked backend respond to every request that
+// matches a registered mock with GitHub's `403` abuse-detection error,
+// carrying the delay both as a `Retry-After` header (the one `go-github`
+// reads into `AbuseRateLimitError.RetryAfter`) and as `retry_after` (in
+// seconds) in the JSON body. Requests that match no registered mock still
+// get the NotFoundHandler's 404.
+func WithAbuseDetection(retryAfter time.Duration) MockBackendOption {
+	return func(router *mux.Router) {
+		router.Use(func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Retry-After", strconv.Itoa(int(retryAfter.Seconds())))
+				writeRateLimitError(w, http.StatusForbidden, rateLimitErrorBody{
+					Message:          "You have triggered an abuse detection mechanism and have been temporarily blocked from content creation. Please retry your request again later.",
+					DocumentationURL: "https://docs.github.com/rest/overview/resources-in-the-rest-api#abuse-rate-limits",
+					RetryAfter:       retryAfter.Seconds(),
+				})
+			})
+		})
+	}
+}
+
+// WithConditionalResponse wraps the mocked backend so that any request
+// that matches a registered mock and whose `If-None-Match` header matches
+// etag receives a bare `304 Not Modified` instead of reaching the
+// underlying handler, exercising `go-github`'s ETag conditional-request
+// support. Requests that match no registered mock still get the
+// NotFoundHandler's 404.
+func WithConditionalResponse(etag string) MockBackendOption {
+	return func(router *mux.Router) {
+		router.Use(func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("ETag", etag)
+
+				if r.Header.Get("If-None-Match") == etag {
+					w.WriteHeader(http.StatusNotModified)
+					return
+				}
+
+				next.ServeHTTP(w, r)
+			})
+		})
+	}
+}
+
+func writeRateLimitError(w http.ResponseWriter, status int, body rateLimitErrorBody) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	w.Write(MustMarshal(body))
+}
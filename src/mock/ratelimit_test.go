@@ -0,0 +1,131 @@
+package mock
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v37/github"
+)
+
+func TestWithRateLimitHeadersAndExhaustion(t *testing.T) {
+	mockedHTTPClient := NewMockedHTTPClient(
+		WithRateLimit(5000, 1, time.Now().Add(time.Hour)),
+		WithRequestMatch(
+			GetUsersByUsername,
+			[][]byte{
+				MustMarshal(github.User{Name: github.String("foobar")}),
+				MustMarshal(github.User{Name: github.String("foobar")}),
+			},
+		),
+	)
+
+	c := github.NewClient(mockedHTTPClient)
+	ctx := context.Background()
+
+	_, resp, err := c.Users.Get(ctx, "someUser")
+
+	if err != nil {
+		t.Fatalf("first call err is %s, want nil", err.Error())
+	}
+
+	if resp.Header.Get("X-RateLimit-Limit") != "5000" {
+		t.Errorf("X-RateLimit-Limit is %s, want 5000", resp.Header.Get("X-RateLimit-Limit"))
+	}
+
+	if resp.Header.Get("X-RateLimit-Remaining") != "0" {
+		t.Errorf("X-RateLimit-Remaining is %s, want 0", resp.Header.Get("X-RateLimit-Remaining"))
+	}
+
+	_, _, err = c.Users.Get(ctx, "someUser")
+
+	if err == nil {
+		t.Fatal("second call err is nil, want *github.RateLimitError")
+	}
+
+	if _, ok := err.(*github.RateLimitError); !ok {
+		t.Errorf("second call err is %T, want *github.RateLimitError", err)
+	}
+}
+
+func TestWithSecondaryRateLimit(t *testing.T) {
+	mockedHTTPClient := NewMockedHTTPClient(
+		WithSecondaryRateLimit(30 * time.Second),
+		WithRequestMatch(GetUsersByUsername, [][]byte{MustMarshal(github.User{})}),
+	)
+
+	c := github.NewClient(mockedHTTPClient)
+
+	_, _, err := c.Users.Get(context.Background(), "someUser")
+
+	if err == nil {
+		t.Fatal("err is nil, want *github.AbuseRateLimitError")
+	}
+
+	abuseErr, ok := err.(*github.AbuseRateLimitError)
+	if !ok {
+		t.Fatalf("err is %T, want *github.AbuseRateLimitError", err)
+	}
+
+	if abuseErr.RetryAfter == nil || *abuseErr.RetryAfter != 30*time.Second {
+		t.Errorf("RetryAfter is %v, want 30s", abuseErr.RetryAfter)
+	}
+}
+
+func TestWithAbuseDetection(t *testing.T) {
+	mockedHTTPClient := NewMockedHTTPClient(
+		WithAbuseDetection(45 * time.Second),
+		WithRequestMatch(GetUsersByUsername, [][]byte{MustMarshal(github.User{})}),
+	)
+
+	c := github.NewClient(mockedHTTPClient)
+
+	_, _, err := c.Users.Get(context.Background(), "someUser")
+
+	if err == nil {
+		t.Fatal("err is nil, want *github.AbuseRateLimitError")
+	}
+
+	abuseErr, ok := err.(*github.AbuseRateLimitError)
+	if !ok {
+		t.Fatalf("err is %T, want *github.AbuseRateLimitError", err)
+	}
+
+	if abuseErr.RetryAfter == nil || *abuseErr.RetryAfter != 45*time.Second {
+		t.Errorf("RetryAfter is %v, want 45s", abuseErr.RetryAfter)
+	}
+}
+
+func TestWithConditionalResponse(t *testing.T) {
+	mockedHTTPClient := NewMockedHTTPClient(
+		WithConditionalResponse(`"some-etag"`),
+		WithRequestMatch(GetUsersByUsername, [][]byte{MustMarshal(github.User{Name: github.String("foobar")})}),
+	)
+
+	c := github.NewClient(mockedHTTPClient)
+	ctx := context.Background()
+
+	req, err := c.NewRequest("GET", "users/someUser", nil)
+
+	if err != nil {
+		t.Fatalf("could not build request: %s", err.Error())
+	}
+
+	req.Header.Set("If-None-Match", `"some-etag"`)
+
+	// The client surfaces a matching If-None-Match as a 304 *error*, not a
+	// nil err -- go-github only treats 2xx responses as success.
+	resp, err := c.Do(ctx, req, nil)
+
+	if err == nil {
+		t.Fatal("conditional get err is nil, want a 304 response")
+	}
+
+	if resp.StatusCode != 304 {
+		t.Errorf("status is %d, want 304", resp.StatusCode)
+	}
+
+	if _, ok := err.(*github.ErrorResponse); !ok {
+		t.Errorf("err is %T, want *github.ErrorResponse", err)
+	}
+}
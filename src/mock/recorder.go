@@ -0,0 +1,219 @@
+package mock
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+// scrubbedResponseHeaders lists the response headers a cassette never
+// persists, since they can carry credentials or session state rather than
+// data a replayed request needs.
+var scrubbedResponseHeaders = []string{"Authorization", "Set-Cookie"}
+
+// CassetteEntry is one recorded round-trip: the EndpointPattern it was made
+// against, the query string it carried, and the exact status/headers/body
+// the live API returned for it.
+//
+// Body is stored as a plain []byte rather than json.RawMessage: a 204 (or
+// any other response with an empty or non-JSON body) would otherwise make
+// json.RawMessage.MarshalJSON fail, and that failure aborts Save() for the
+// whole cassette, not just the offending entry.
+type CassetteEntry struct {
+	Pattern    EndpointPattern `json:"pattern"`
+	Query      url.Values      `json:"query,omitempty"`
+	StatusCode int             `json:"status_code"`
+	Header     http.Header     `json:"header,omitempty"`
+	Body       []byte          `json:"body"`
+}
+
+// Cassette is the on-disk JSON representation a Recorder writes to and
+// NewReplayClient reads from.
+type Cassette struct {
+	Entries []CassetteEntry `json:"entries"`
+}
+
+// ScrubFunc redacts or removes sensitive fields from a recorded response
+// body before it's written to a cassette. It receives the body decoded as
+// generic JSON and returns the value that will be persisted in its place.
+type ScrubFunc func(body interface{}) interface{}
+
+// Recorder wraps an authenticated http.RoundTripper -- typically one
+// already configured to hit api.github.com -- transparently proxying every
+// request while appending a CassetteEntry for it. Save persists what's
+// been recorded so far to Cassette, turning a single real run into
+// fixtures a NewReplayClient can serve from later.
+type Recorder struct {
+	Upstream http.RoundTripper
+	Cassette string
+	Scrub    ScrubFunc
+
+	mu      sync.Mutex
+	entries []CassetteEntry
+}
+
+// NewRecorder wraps upstream so every request it serves is also appended
+// to an in-memory cassette that Save persists to cassettePath.
+func NewRecorder(upstream http.RoundTripper, cassettePath string) *Recorder {
+	return &Recorder{Upstream: upstream, Cassette: cassettePath}
+}
+
+// RoundTrip implementation of `http.RoundTripper`
+func (rec *Recorder) RoundTrip(r *http.Request) (*http.Response, error) {
+	resp, err := rec.Upstream.RoundTrip(r)
+	if err != nil {
+		return resp, err
+	}
+
+	body, readErr := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if readErr != nil {
+		return resp, readErr
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	rec.record(r, resp.StatusCode, resp.Header, body)
+
+	return resp, nil
+}
+
+func (rec *Recorder) record(r *http.Request, status int, header http.Header, body []byte) {
+	persisted := body
+
+	if rec.Scrub != nil {
+		var decoded interface{}
+		if json.Unmarshal(body, &decoded) == nil {
+			persisted = MustMarshal(rec.Scrub(decoded))
+		}
+	}
+
+	entry := CassetteEntry{
+		Pattern:    EndpointPattern{Method: r.Method, Pattern: r.URL.Path},
+		Query:      r.URL.Query(),
+		StatusCode: status,
+		Header:     scrubHeaders(header),
+		Body:       persisted,
+	}
+
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+
+	rec.entries = append(rec.entries, entry)
+}
+
+// scrubHeaders returns a copy of h with scrubbedResponseHeaders removed, so
+// a cassette is safe to commit without leaking credentials or session
+// state captured from the live response.
+func scrubHeaders(h http.Header) http.Header {
+	cloned := h.Clone()
+	for _, name := range scrubbedResponseHeaders {
+		cloned.Del(name)
+	}
+	return cloned
+}
+
+// Save writes every entry recorded so far to rec.Cassette as indented JSON.
+func (rec *Recorder) Save() error {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+
+	data, err := json.MarshalIndent(Cassette{Entries: rec.entries}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("go-github-mock: could not marshal cassette: %w", err)
+	}
+
+	if err := ioutil.WriteFile(rec.Cassette, data, 0o644); err != nil {
+		return fmt.Errorf("go-github-mock: could not write cassette %s: %w", rec.Cassette, err)
+	}
+
+	return nil
+}
+
+// ReplayMode controls how a client built by NewReplayClient handles a
+// request no cassette entry matches.
+type ReplayMode int
+
+const (
+	// ReplayStrict makes an unmatched request 404, mirroring
+	// NewMockedHTTPClient's NotFoundHandler.
+	ReplayStrict ReplayMode = iota
+	// ReplayLax lets an unmatched request fall through to the real network.
+	ReplayLax
+)
+
+// NewReplayClient constructs an *http.Client, identical in shape to
+// NewMockedHTTPClient, that serves responses from the cassette at
+// cassettePath instead of canned WithRequestMatch options.
+func NewReplayClient(cassettePath string, mode ReplayMode) (*http.Client, error) {
+	data, err := ioutil.ReadFile(cassettePath)
+	if err != nil {
+		return nil, fmt.Errorf("go-github-mock: could not read cassette %s: %w", cassettePath, err)
+	}
+
+	var cassette Cassette
+	if err := json.Unmarshal(data, &cassette); err != nil {
+		return nil, fmt.Errorf("go-github-mock: could not parse cassette %s: %w", cassettePath, err)
+	}
+
+	return &http.Client{
+		Transport: &replayRoundTripper{entries: cassette.Entries, mode: mode},
+	}, nil
+}
+
+type replayRoundTripper struct {
+	entries []CassetteEntry
+	mode    ReplayMode
+}
+
+// RoundTrip implementation of `http.RoundTripper`
+func (rt *replayRoundTripper) RoundTrip(r *http.Request) (*http.Response, error) {
+	for _, e := range rt.entries {
+		if e.Pattern.Method != r.Method || e.Pattern.Pattern != r.URL.Path {
+			continue
+		}
+
+		if e.Query.Encode() != r.URL.Query().Encode() {
+			continue
+		}
+
+		return &http.Response{
+			StatusCode: e.StatusCode,
+			Status:     http.StatusText(e.StatusCode),
+			Body:       ioutil.NopCloser(bytes.NewReader(e.Body)),
+			Header:     replayHeader(e.Header),
+			Request:    r,
+		}, nil
+	}
+
+	if rt.mode == ReplayLax {
+		return http.DefaultTransport.RoundTrip(r)
+	}
+
+	return &http.Response{
+		StatusCode: http.StatusNotFound,
+		Status:     http.StatusText(http.StatusNotFound),
+		Body: ioutil.NopCloser(bytes.NewReader(MustMarshal(map[string]string{
+			"message": fmt.Sprintf("go-github-mock: no cassette entry for %s %s", r.Method, r.URL.Path),
+		}))),
+		Header:  http.Header{"Content-Type": []string{"application/json"}},
+		Request: r,
+	}, nil
+}
+
+// replayHeader returns the headers a cassette entry was recorded with,
+// defaulting Content-Type to application/json for cassettes recorded
+// before CassetteEntry captured response headers.
+func replayHeader(recorded http.Header) http.Header {
+	header := recorded.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	if header.Get("Content-Type") == "" {
+		header.Set("Content-Type", "application/json")
+	}
+	return header
+}
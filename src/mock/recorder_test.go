@@ -0,0 +1,190 @@
+package mock
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/google/go-github/v37/github"
+)
+
+func TestRecorderSaveAndReplay(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); auth != "token super-secret" {
+			t.Errorf("upstream saw Authorization %q, want it set", auth)
+		}
+
+		w.Write(MustMarshal(github.User{Name: github.String("foobar")}))
+	}))
+	defer upstream.Close()
+
+	cassettePath, err := ioutil.TempFile("", "go-github-mock-cassette-*.json")
+	if err != nil {
+		t.Fatalf("could not create temp cassette: %s", err.Error())
+	}
+	cassettePath.Close()
+	defer os.Remove(cassettePath.Name())
+
+	rec := NewRecorder(http.DefaultTransport, cassettePath.Name())
+
+	client := &http.Client{Transport: &EnforceHostRoundTripper{Host: upstream.URL, UpstreamRoundTripper: rec}}
+
+	req, err := http.NewRequest(http.MethodGet, "/users/someUser", nil)
+	if err != nil {
+		t.Fatalf("could not build request: %s", err.Error())
+	}
+	req.Header.Set("Authorization", "token super-secret")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("recorded request err is %s, want nil", err.Error())
+	}
+	resp.Body.Close()
+
+	if err := rec.Save(); err != nil {
+		t.Fatalf("could not save cassette: %s", err.Error())
+	}
+
+	replayClient, err := NewReplayClient(cassettePath.Name(), ReplayStrict)
+	if err != nil {
+		t.Fatalf("could not build replay client: %s", err.Error())
+	}
+
+	c := github.NewClient(replayClient)
+
+	user, _, err := c.Users.Get(context.Background(), "someUser")
+	if err != nil {
+		t.Fatalf("replayed request err is %s, want nil", err.Error())
+	}
+
+	if user.GetName() != "foobar" {
+		t.Errorf("replayed user name is %s, want foobar", user.GetName())
+	}
+}
+
+func TestRecorderReplaysHeadersAndScrubsSensitiveOnes(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"some-etag"`)
+		w.Header().Set("X-RateLimit-Remaining", "42")
+		w.Header().Set("Set-Cookie", "session=super-secret")
+		w.Write(MustMarshal(github.User{Name: github.String("foobar")}))
+	}))
+	defer upstream.Close()
+
+	cassettePath, err := ioutil.TempFile("", "go-github-mock-cassette-*.json")
+	if err != nil {
+		t.Fatalf("could not create temp cassette: %s", err.Error())
+	}
+	cassettePath.Close()
+	defer os.Remove(cassettePath.Name())
+
+	rec := NewRecorder(http.DefaultTransport, cassettePath.Name())
+
+	client := &http.Client{Transport: &EnforceHostRoundTripper{Host: upstream.URL, UpstreamRoundTripper: rec}}
+
+	req, err := http.NewRequest(http.MethodGet, "/users/someUser", nil)
+	if err != nil {
+		t.Fatalf("could not build request: %s", err.Error())
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("recorded request err is %s, want nil", err.Error())
+	}
+	resp.Body.Close()
+
+	if err := rec.Save(); err != nil {
+		t.Fatalf("could not save cassette: %s", err.Error())
+	}
+
+	replayClient, err := NewReplayClient(cassettePath.Name(), ReplayStrict)
+	if err != nil {
+		t.Fatalf("could not build replay client: %s", err.Error())
+	}
+
+	replayedReq, err := http.NewRequest(http.MethodGet, "http://mock.localhost/users/someUser", nil)
+	if err != nil {
+		t.Fatalf("could not build replayed request: %s", err.Error())
+	}
+
+	replayedResp, err := replayClient.Do(replayedReq)
+	if err != nil {
+		t.Fatalf("replayed request err is %s, want nil", err.Error())
+	}
+	defer replayedResp.Body.Close()
+
+	if got := replayedResp.Header.Get("ETag"); got != `"some-etag"` {
+		t.Errorf("replayed ETag is %q, want \"some-etag\"", got)
+	}
+
+	if got := replayedResp.Header.Get("X-RateLimit-Remaining"); got != "42" {
+		t.Errorf("replayed X-RateLimit-Remaining is %q, want 42", got)
+	}
+
+	if got := replayedResp.Header.Get("Set-Cookie"); got != "" {
+		t.Errorf("replayed Set-Cookie is %q, want it scrubbed", got)
+	}
+}
+
+func TestRecorderHandlesEmptyBody(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer upstream.Close()
+
+	cassettePath, err := ioutil.TempFile("", "go-github-mock-cassette-*.json")
+	if err != nil {
+		t.Fatalf("could not create temp cassette: %s", err.Error())
+	}
+	cassettePath.Close()
+	defer os.Remove(cassettePath.Name())
+
+	rec := NewRecorder(http.DefaultTransport, cassettePath.Name())
+
+	client := &http.Client{Transport: &EnforceHostRoundTripper{Host: upstream.URL, UpstreamRoundTripper: rec}}
+
+	req, err := http.NewRequest(http.MethodDelete, "/repos/o/r/issues/1/lock", nil)
+	if err != nil {
+		t.Fatalf("could not build request: %s", err.Error())
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("recorded request err is %s, want nil", err.Error())
+	}
+	resp.Body.Close()
+
+	if err := rec.Save(); err != nil {
+		t.Fatalf("could not save cassette with an empty-body entry: %s", err.Error())
+	}
+}
+
+func TestNewReplayClientStrictModeNotFound(t *testing.T) {
+	cassettePath, err := ioutil.TempFile("", "go-github-mock-cassette-*.json")
+	if err != nil {
+		t.Fatalf("could not create temp cassette: %s", err.Error())
+	}
+	cassettePath.Write(MustMarshal(Cassette{}))
+	cassettePath.Close()
+	defer os.Remove(cassettePath.Name())
+
+	replayClient, err := NewReplayClient(cassettePath.Name(), ReplayStrict)
+	if err != nil {
+		t.Fatalf("could not build replay client: %s", err.Error())
+	}
+
+	c := github.NewClient(replayClient)
+
+	_, resp, err := c.Users.Get(context.Background(), "someUser")
+
+	if err == nil {
+		t.Fatal("err is nil, want a 404 response")
+	}
+
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("status is %d, want 404", resp.StatusCode)
+	}
+}
@@ -0,0 +1,188 @@
+package mock
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/gorilla/mux"
+)
+
+// Call records a single HTTP request that hit a backend built from a
+// Registry, matched or not.
+type Call struct {
+	Pattern EndpointPattern
+	Query   url.Values
+	Body    []byte
+}
+
+// FormValue returns the recorded call's value for key: it checks the URL
+// query string first, then falls back to the call's JSON body -- the shape
+// `go-github` actually sends for most REST calls -- so
+// `calls[0].FormValue("title")` works against, say, a `POST .../issues`
+// call carrying `{"title": "bar"}`.
+func (c Call) FormValue(key string) string {
+	if v := c.Query.Get(key); v != "" {
+		return v
+	}
+
+	var body map[string]interface{}
+	if json.Unmarshal(c.Body, &body) != nil {
+		return ""
+	}
+
+	v, ok := body[key]
+	if !ok {
+		return ""
+	}
+
+	if s, ok := v.(string); ok {
+		return s
+	}
+
+	return fmt.Sprint(v)
+}
+
+// expectation tracks how many times a mock registered through
+// WithRequestMatchExpect was expected -- and was actually -- called.
+type expectation struct {
+	pattern   EndpointPattern
+	wantTimes int
+	gotTimes  int
+}
+
+// Registry is an expectations/verification layer over NewMockedHTTPClient,
+// akin to `httpmock.Registry.Verify` in `cli/cli`. It records the requests
+// served by mocks registered through WithRequestMatchExpect, and every
+// request that matches no mock, so tests can assert that every expected
+// mock was actually called, and can inspect the calls it received.
+type Registry struct {
+	mu           sync.Mutex
+	expectations []*expectation
+	calls        []Call
+	unexpected   []Call
+}
+
+// NewRegistry creates an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// NewMockedHTTPClient behaves like the package-level NewMockedHTTPClient,
+// but requests that match no option are recorded on reg as unexpected
+// calls, which make Verify fail. Requests served by mocks from
+// reg.WithRequestMatchExpect are recorded for Calls and Verify; requests
+// served by other options (e.g. WithRequestMatch) are not recorded.
+func (reg *Registry) NewMockedHTTPClient(options ...MockBackendOption) *http.Client {
+	return newMockedHTTPClient(func(w http.ResponseWriter, r *http.Request) {
+		reg.recordUnexpected(r)
+		defaultNotFoundHandler(w, r)
+	}, options...)
+}
+
+// WithRequestMatchExpect registers a mock for pattern, responding with
+// responses like WithRequestMatch, and records an expectation that Verify
+// checks later. If times is 0, Verify only requires pattern to have been
+// called at least once; otherwise it must be called exactly times times.
+func (reg *Registry) WithRequestMatchExpect(
+	pattern EndpointPattern,
+	times int,
+	responses ...[]byte,
+) MockBackendOption {
+	exp := &expectation{pattern: pattern, wantTimes: times}
+
+	reg.mu.Lock()
+	reg.expectations = append(reg.expectations, exp)
+	reg.mu.Unlock()
+
+	handler := &FIFOReponseHandler{Responses: responses}
+
+	return func(router *mux.Router) {
+		router.
+			Methods(pattern.Method).
+			Path(pattern.Pattern).
+			HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				reg.record(pattern, exp, r)
+				handler.ServeHTTP(w, r)
+			})
+	}
+}
+
+func (reg *Registry) record(pattern EndpointPattern, exp *expectation, r *http.Request) {
+	body, _ := ioutil.ReadAll(r.Body)
+
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+
+	reg.calls = append(reg.calls, Call{
+		Pattern: pattern,
+		Query:   r.URL.Query(),
+		Body:    body,
+	})
+
+	exp.gotTimes++
+}
+
+func (reg *Registry) recordUnexpected(r *http.Request) {
+	body, _ := ioutil.ReadAll(r.Body)
+
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+
+	reg.unexpected = append(reg.unexpected, Call{
+		Pattern: EndpointPattern{Method: r.Method, Pattern: r.URL.Path},
+		Query:   r.URL.Query(),
+		Body:    body,
+	})
+}
+
+// Calls returns every recorded call made against pattern, in the order they
+// were received.
+func (reg *Registry) Calls(pattern EndpointPattern) []Call {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+
+	var matched []Call
+	for _, c := range reg.calls {
+		if c.Pattern == pattern {
+			matched = append(matched, c)
+		}
+	}
+
+	return matched
+}
+
+// Verify fails t if any expectation registered through WithRequestMatchExpect
+// was not met, or if any request reached the NotFoundHandler.
+func (reg *Registry) Verify(t *testing.T) {
+	t.Helper()
+
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+
+	for _, exp := range reg.expectations {
+		switch {
+		case exp.wantTimes == 0 && exp.gotTimes == 0:
+			t.Errorf(
+				"go-github-mock: expected %s %s to be called, but it was not",
+				exp.pattern.Method, exp.pattern.Pattern,
+			)
+		case exp.wantTimes > 0 && exp.gotTimes != exp.wantTimes:
+			t.Errorf(
+				"go-github-mock: expected %s %s to be called %d time(s), got %d",
+				exp.pattern.Method, exp.pattern.Pattern, exp.wantTimes, exp.gotTimes,
+			)
+		}
+	}
+
+	for _, call := range reg.unexpected {
+		t.Errorf(
+			"go-github-mock: unexpected call to %s %s",
+			call.Pattern.Method, call.Pattern.Pattern,
+		)
+	}
+}
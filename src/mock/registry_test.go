@@ -0,0 +1,124 @@
+package mock
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-github/v37/github"
+)
+
+func TestRegistryVerifySucceeds(t *testing.T) {
+	reg := NewRegistry()
+
+	mockedHTTPClient := reg.NewMockedHTTPClient(
+		reg.WithRequestMatchExpect(
+			GetUsersByUsername,
+			1,
+			MustMarshal(github.User{Name: github.String("foobar")}),
+		),
+	)
+
+	c := github.NewClient(mockedHTTPClient)
+
+	if _, _, err := c.Users.Get(context.Background(), "someUser"); err != nil {
+		t.Fatalf("user err is %s, want nil", err.Error())
+	}
+
+	reg.Verify(t)
+
+	calls := reg.Calls(GetUsersByUsername)
+
+	if len(calls) != 1 {
+		t.Errorf("len(calls) is %d, want 1", len(calls))
+	}
+}
+
+func TestRegistryCallFormValueFallsBackToJSONBody(t *testing.T) {
+	reg := NewRegistry()
+
+	mockedHTTPClient := reg.NewMockedHTTPClient(
+		reg.WithRequestMatchExpect(
+			PostReposIssuesByOwnerByRepo,
+			1,
+			MustMarshal(github.Issue{Number: github.Int(1), Title: github.String("bar")}),
+		),
+	)
+
+	c := github.NewClient(mockedHTTPClient)
+
+	if _, _, err := c.Issues.Create(context.Background(), "o", "r", &github.IssueRequest{Title: github.String("bar")}); err != nil {
+		t.Fatalf("create issue err is %s, want nil", err.Error())
+	}
+
+	calls := reg.Calls(PostReposIssuesByOwnerByRepo)
+
+	if len(calls) != 1 {
+		t.Fatalf("len(calls) is %d, want 1", len(calls))
+	}
+
+	if got := calls[0].FormValue("title"); got != "bar" {
+		t.Errorf("calls[0].FormValue(\"title\") is %q, want \"bar\"", got)
+	}
+}
+
+func TestRegistryVerifyFailsWhenUncalled(t *testing.T) {
+	reg := NewRegistry()
+
+	reg.NewMockedHTTPClient(
+		reg.WithRequestMatchExpect(
+			GetUsersByUsername,
+			0,
+			MustMarshal(github.User{Name: github.String("foobar")}),
+		),
+	)
+
+	fakeT := &testing.T{}
+	reg.Verify(fakeT)
+
+	if !fakeT.Failed() {
+		t.Error("Verify should have failed the test, but it did not")
+	}
+}
+
+func TestRegistryVerifyFailsOnUnexpectedCall(t *testing.T) {
+	reg := NewRegistry()
+
+	mockedHTTPClient := reg.NewMockedHTTPClient()
+
+	c := github.NewClient(mockedHTTPClient)
+
+	_, _, _ = c.Users.Get(context.Background(), "someUser")
+
+	fakeT := &testing.T{}
+	reg.Verify(fakeT)
+
+	if !fakeT.Failed() {
+		t.Error("Verify should have failed the test due to an unexpected call, but it did not")
+	}
+}
+
+func TestRegistryVerifyFailsOnWrongCallCount(t *testing.T) {
+	reg := NewRegistry()
+
+	mockedHTTPClient := reg.NewMockedHTTPClient(
+		reg.WithRequestMatchExpect(
+			GetUsersByUsername,
+			2,
+			MustMarshal(github.User{Name: github.String("foobar")}),
+			MustMarshal(github.User{Name: github.String("foobar")}),
+		),
+	)
+
+	c := github.NewClient(mockedHTTPClient)
+
+	if _, _, err := c.Users.Get(context.Background(), "someUser"); err != nil {
+		t.Fatalf("user err is %s, want nil", err.Error())
+	}
+
+	fakeT := &testing.T{}
+	reg.Verify(fakeT)
+
+	if !fakeT.Failed() {
+		t.Error("Verify should have failed the test because the mock was expected twice, but it did not")
+	}
+}
@@ -166,15 +166,24 @@ func (efrt *EnforceHostRoundTripper) RoundTrip(r *http.Request) (*http.Response,
 //
 // c := github.NewClient(mockedHTTPClient)
 func NewMockedHTTPClient(options ...MockBackendOption) *http.Client {
+	return newMockedHTTPClient(defaultNotFoundHandler, options...)
+}
+
+func defaultNotFoundHandler(w http.ResponseWriter, r *http.Request) {
+	WriteError(
+		w,
+		http.StatusNotFound,
+		fmt.Sprintf("mock response not found for %s", r.URL.Path),
+	)
+}
+
+// newMockedHTTPClient is the shared implementation behind NewMockedHTTPClient
+// and Registry.NewMockedHTTPClient, parameterized on the NotFoundHandler so
+// callers can observe unmatched requests.
+func newMockedHTTPClient(notFoundHandler http.HandlerFunc, options ...MockBackendOption) *http.Client {
 	router := mux.NewRouter()
 
-	router.NotFoundHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		WriteError(
-			w,
-			http.StatusNotFound,
-			fmt.Sprintf("mock response not found for %s", r.URL.Path),
-		)
-	})
+	router.NotFoundHandler = notFoundHandler
 
 	for _, o := range options {
 		o(router)
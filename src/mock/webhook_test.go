@@ -0,0 +1,94 @@
+package mock
+
+import (
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-github/v37/github"
+)
+
+func TestWebhookServerSendEventValidatesSignature(t *testing.T) {
+	const secret = "it's a secret to everybody"
+
+	var gotEvent string
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		payload, err := github.ValidatePayload(r, []byte(secret))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		gotEvent = github.WebHookType(r)
+
+		event, err := github.ParseWebHook(gotEvent, payload)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if _, ok := event.(*github.PushEvent); !ok {
+			http.Error(w, "unexpected event type", http.StatusBadRequest)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+
+	ws := NewWebhookServer(handler, WithWebhookSecret(secret))
+
+	resp := ws.SendEvent(EventPush, &github.PushEvent{
+		Ref: github.String("refs/heads/main"),
+	})
+
+	if resp.Code != http.StatusOK {
+		t.Fatalf("status is %d, want 200, body: %s", resp.Code, resp.Body.String())
+	}
+
+	if gotEvent != EventPush {
+		t.Errorf("event type is %s, want %s", gotEvent, EventPush)
+	}
+}
+
+func TestWebhookServerSendEventRejectsBadSignature(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := github.ValidatePayload(r, []byte("the-real-secret")); err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+
+	ws := NewWebhookServer(handler, WithWebhookSecret("not-the-real-secret"))
+
+	resp := ws.SendEvent(EventPing, &github.PingEvent{})
+
+	if resp.Code != http.StatusUnauthorized {
+		t.Errorf("status is %d, want 401", resp.Code)
+	}
+}
+
+func TestWebhookServerReplay(t *testing.T) {
+	recorded := []byte(`{"action": "opened", "number": 1}`)
+
+	var gotBody []byte
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotBody, _ = ioutil.ReadAll(r.Body)
+		w.WriteHeader(http.StatusOK)
+	})
+
+	ws := NewWebhookServer(handler)
+
+	resp := ws.Replay(EventPullRequest, recorded)
+
+	if resp.Code != http.StatusOK {
+		t.Fatalf("status is %d, want 200", resp.Code)
+	}
+
+	if string(gotBody) != string(recorded) {
+		t.Errorf("replayed body is %s, want %s verbatim", gotBody, recorded)
+	}
+}